@@ -5,128 +5,97 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/hairutdin/alexios/internal/logger"
 	"github.com/hairutdin/alexios/internal/models"
+	"github.com/hairutdin/alexios/internal/nlu"
 	"github.com/hairutdin/alexios/internal/store"
-	"go.uber.org/zap"
 )
 
 type app struct {
-	store store.Store
+	store  store.Store
+	parser nlu.Parser
 }
 
 func newApp(s store.Store) *app {
-	return &app{store: s}
-}
-
-func parseSendCommand(command string) (string, string) {
-	// Example of a command: "Send John Hello, how are you?"
-	// Split the command into parts
-	parts := strings.SplitN(command, " ", 3)
-
-	// Ensure the command is well-formed
-	if len(parts) < 3 {
-		return "", ""
-	}
-
-	// The second part should be the recipient's username
-	username := parts[1]
-
-	// The third part should be the message text
-	message := parts[2]
-
-	return username, message
-}
-
-func parseReadCommand(command string) int {
-	// Example of a command: "Read 1"
-	// Split the command into parts
-	parts := strings.Split(command, " ")
-
-	// Ensure the command is well-formed
-	if len(parts) < 2 {
-		return -1 // Return an invalid index if the command is incorrect
+	parser, err := nlu.NewParser()
+	if err != nil {
+		// The locale dictionaries are embedded at build time, so this
+		// can only fail if the embedded JSON itself is malformed.
+		panic(err)
 	}
 
-	// The second part should be the message index
-	index, err := strconv.Atoi(parts[1])
-	if err != nil || index < 1 {
-		return -1 // Return an invalid index if the conversion fails or index is less than 1
-	}
-
-	return index - 1 // Convert to zero-based index
-}
-
-func parseRegisterCommand(command string) string {
-	// Example of a command: "Sign Up JohnDoe"
-	// Split the command into parts
-	parts := strings.SplitN(command, " ", 3)
-
-	// Ensure the command is well-formed
-	if len(parts) < 3 {
-		return ""
-	}
-
-	// The third part should be the username
-	username := parts[2]
-
-	return username
+	return &app{store: s, parser: parser}
 }
 
 func (a *app) webhook(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	start := time.Now()
+	rid := logger.RequestID(ctx)
 
 	if r.Method != http.MethodPost {
-		logger.Log.Debug("got request with bad method", zap.String("method", r.Method))
+		logger.Log.Debug().Str("request_id", rid).Str("method", r.Method).Msg("got request with bad method")
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	logger.Log.Debug("decoding request")
 	var req models.Request
 	dec := json.NewDecoder(r.Body)
 	if err := dec.Decode(&req); err != nil {
-		logger.Log.Debug("cannot decode request JSON body", zap.Error(err))
+		logger.Log.Debug().Str("request_id", rid).Err(err).Msg("decode: cannot decode request JSON body")
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	if req.Request.Type != models.TypeSimpleUtterance {
-		logger.Log.Debug("unsupported request type", zap.String("type", req.Request.Type))
+		logger.Log.Debug().Str("request_id", rid).Str("type", req.Request.Type).Msg("decode: unsupported request type")
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		return
 	}
 
+	userID := req.Session.User.UserID
+	sessionID := req.Session.SessionID
+
+	logger.Log.Debug().
+		Str("request_id", rid).
+		Str("user_id", userID).
+		Str("session_id", sessionID).
+		Msg("decode: request decoded")
+
+	result := a.parser.Parse(req.Request.Command)
+
+	logger.Log.Debug().
+		Str("request_id", rid).
+		Str("user_id", userID).
+		Str("session_id", sessionID).
+		Str("intent", string(result.Intent)).
+		Msg("parse: command parsed")
+
 	// skill response text
 	var text string
 
-	switch true {
+	switch result.Intent {
 	// user asked to send a message
-	case strings.HasPrefix(req.Request.Command, "Send"):
-		// the hypothetical function parseSendCommand extracts
-		// the recipient's login and the message text from the request
-		username, message := parseSendCommand(req.Request.Command)
+	case nlu.IntentSendMessage:
+		username, message := result.Slots.Recipient, result.Slots.Message
 
 		// find the internal identifier of the addressee by his login name
 		recepientID, err := a.store.FindRecepient(ctx, username)
 		if err != nil {
-			logger.Log.Debug("cannot find recepient by username", zap.String("username", username), zap.Error(err))
+			logger.Log.Debug().Str("request_id", rid).Str("username", username).Err(err).Msg("store: cannot find recepient by username")
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		// save the new message in DBMS, after successful saving it will become available for listening by the recipient
 		err = a.store.SaveMessage(ctx, recepientID, store.Message{
-			Sender:  req.Session.User.UserID,
+			Sender:  userID,
 			Time:    time.Now(),
 			Payload: message,
 		})
 		if err != nil {
-			logger.Log.Debug("cannot save message", zap.String("recepient", recepientID), zap.Error(err))
+			logger.Log.Debug().Str("request_id", rid).Str("recepient", recepientID).Err(err).Msg("store: cannot save message")
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -135,21 +104,24 @@ func (a *app) webhook(w http.ResponseWriter, r *http.Request) {
 		text = "The message was sent successfully."
 
 	// user asked to read a message
-	case strings.HasPrefix(req.Request.Command, "Read"):
-		// the hypothetical function parseReadCommand extracts from the request
-		// the sequence number of the message in the list of available messages.
-		messageIndex := parseReadCommand(req.Request.Command)
+	case nlu.IntentReadMessage:
+		messageIndex := result.Slots.Index
 
-		// get the list of unheard messages of the user
-		messages, err := a.store.ListMessages(ctx, req.Session.User.UserID)
+		// get the list of unread messages of the user, so "Read N" and
+		// the message count it's indexed against agree with each other
+		messages, _, err := a.store.ListMessagesPage(ctx, userID, store.ListOpts{UnreadOnly: true})
 		if err != nil {
-			logger.Log.Debug("cannot load messages for user", zap.Error(err))
+			logger.Log.Debug().Str("request_id", rid).Err(err).Msg("store: cannot load messages for user")
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		if messageIndex == nlu.IndexLast {
+			messageIndex = len(messages) - 1
+		}
+
 		text = "There is no new messages for you."
-		if len(messages) < messageIndex {
+		if messageIndex < 0 || len(messages) <= messageIndex {
 			// the user has asked to read a message that does not exist
 			text = "There is no such a message."
 		} else {
@@ -158,25 +130,29 @@ func (a *app) webhook(w http.ResponseWriter, r *http.Request) {
 			messageID := messages[messageIndex].ID
 			message, err := a.store.GetMessage(ctx, messageID)
 			if err != nil {
-				logger.Log.Debug("cannot load message", zap.Int64("id", messageID), zap.Error(err))
+				logger.Log.Debug().Str("request_id", rid).Int64("id", messageID).Err(err).Msg("store: cannot load message")
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 
 			// pass the text of the message in the reply
 			text = fmt.Sprintf("Message from %s, was sent at %s: %s", message.Sender, message.Time, message.Payload)
+
+			// best-effort: a failed read receipt shouldn't stop us from
+			// telling the user what the message said
+			if err := a.store.MarkRead(ctx, messageID); err != nil {
+				logger.Log.Debug().Str("request_id", rid).Int64("id", messageID).Err(err).Msg("store: cannot mark message as read")
+			}
 		}
 	//	the user wants to register
-	case strings.HasPrefix(req.Request.Command, "Sign Up"):
-		// the hypothetical function parseRegisterCommand extracts
-		// from the request the desired name of the new user
-		username := parseRegisterCommand(req.Request.Command)
+	case nlu.IntentRegister:
+		username := result.Slots.Username
 
 		// register a user
-		err := a.store.RegisterUser(ctx, req.Session.User.UserID, username)
+		err := a.store.RegisterUser(ctx, userID, username)
 		// presence of a nonspecific error
 		if err != nil && !errors.Is(err, store.ErrConflict) {
-			logger.Log.Debug("cannot register user", zap.Error(err))
+			logger.Log.Debug().Str("request_id", rid).Err(err).Msg("store: cannot register user")
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -186,11 +162,12 @@ func (a *app) webhook(w http.ResponseWriter, r *http.Request) {
 			text = "Sorry, this name has already been used. Try another name."
 		}
 
-	// if the command is not understood, just tell the user how many new messages a user has
-	default:
-		messages, err := a.store.ListMessages(ctx, req.Session.User.UserID)
+	// if the command is not understood (or is a plain "list messages" ask),
+	// just tell the user how many new messages they have
+	case nlu.IntentListMessages, nlu.IntentUnknown:
+		messages, _, err := a.store.ListMessagesPage(ctx, userID, store.ListOpts{UnreadOnly: true})
 		if err != nil {
-			logger.Log.Debug("cannot load messages for user", zap.Error(err))
+			logger.Log.Debug().Str("request_id", rid).Err(err).Msg("store: cannot load messages for user")
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -204,7 +181,7 @@ func (a *app) webhook(w http.ResponseWriter, r *http.Request) {
 		if req.Session.New {
 			tz, err := time.LoadLocation(req.Timezone)
 			if err != nil {
-				logger.Log.Debug("cannot parse timezone")
+				logger.Log.Debug().Str("request_id", rid).Msg("store: cannot parse timezone")
 				w.WriteHeader(http.StatusBadRequest)
 				return
 			}
@@ -226,8 +203,15 @@ func (a *app) webhook(w http.ResponseWriter, r *http.Request) {
 
 	enc := json.NewEncoder(w)
 	if err := enc.Encode(resp); err != nil {
-		logger.Log.Debug("error encoding response", zap.Error(err))
+		logger.Log.Debug().Str("request_id", rid).Err(err).Msg("respond: error encoding response")
 		return
 	}
-	logger.Log.Debug("sending HTTP 200 response")
+
+	logger.Log.Debug().
+		Str("request_id", rid).
+		Str("user_id", userID).
+		Str("session_id", sessionID).
+		Str("intent", string(result.Intent)).
+		Dur("latency", time.Since(start)).
+		Msg("respond: sent HTTP 200 response")
 }
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"time"
+)
+
+var (
+	flagRunAddr     string
+	flagDatabaseURI string
+	flagLogLevel    string
+	flagLogFormat   string
+	flagStoreDriver string
+
+	flagAPIAddr   string
+	flagJWTSecret string
+	flagJWTTTL    time.Duration
+)
+
+func parseFlags() {
+	flag.StringVar(&flagRunAddr, "a", "localhost:8080", "address and port to run the Alice webhook server")
+	flag.StringVar(&flagDatabaseURI, "d", "", "store connection string (DSN), meaning depends on -store")
+	flag.StringVar(&flagLogLevel, "l", "info", "logging level")
+	flag.StringVar(&flagLogFormat, "log-format", "console", "log sink format: json|console")
+	flag.StringVar(&flagStoreDriver, "store", "pg", "message store backend: pg|memory|sqlite|redis")
+
+	flag.StringVar(&flagAPIAddr, "api-addr", "localhost:8081", "address and port to run the REST API server")
+	flag.StringVar(&flagJWTSecret, "jwt-secret", "", "HS256 secret used to sign API access tokens")
+	flag.DurationVar(&flagJWTTTL, "jwt-ttl", time.Hour, "lifetime of issued API access tokens")
+
+	flag.Parse()
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
-	"database/sql"
 	"net/http"
 	"strings"
 
+	"github.com/hairutdin/alexios/internal/api"
+	"github.com/hairutdin/alexios/internal/auth"
 	"github.com/hairutdin/alexios/internal/logger"
-	"github.com/hairutdin/alexios/internal/store/pg"
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"go.uber.org/zap"
+	"github.com/hairutdin/alexios/internal/store"
+	_ "github.com/hairutdin/alexios/internal/store/memory"
+	_ "github.com/hairutdin/alexios/internal/store/pg"
+	_ "github.com/hairutdin/alexios/internal/store/redis"
+	_ "github.com/hairutdin/alexios/internal/store/sqlite"
 )
 
 func main() {
@@ -48,17 +51,27 @@ func gzipMiddleware(h http.HandlerFunc) http.HandlerFunc {
 }
 
 func run() error {
-	if err := logger.Initialize(flagLogLevel); err != nil {
+	if err := logger.Initialize(flagLogLevel, flagLogFormat); err != nil {
 		return err
 	}
 
-	conn, err := sql.Open("pgx", flagDatabaseURI)
+	messageStore, err := store.Open(flagStoreDriver, flagDatabaseURI)
 	if err != nil {
 		return err
 	}
 
-	appInstance := newApp(pg.NewStore(conn))
+	appInstance := newApp(messageStore)
 
-	logger.Log.Info("Running server", zap.String("address", flagRunAddr))
+	auth.Initialize(flagJWTSecret, flagJWTTTL)
+	apiServer := api.NewServer(messageStore)
+
+	go func() {
+		logger.Log.Info().Str("address", flagAPIAddr).Msg("running API server")
+		if err := http.ListenAndServe(flagAPIAddr, apiServer.Routes()); err != nil {
+			logger.Log.Error().Err(err).Msg("API server stopped")
+		}
+	}()
+
+	logger.Log.Info().Str("address", flagRunAddr).Msg("running server")
 	return http.ListenAndServe(flagRunAddr, logger.RequestLogger(gzipMiddleware(appInstance.webhook)))
 }
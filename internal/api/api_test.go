@@ -0,0 +1,310 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/auth"
+	"github.com/hairutdin/alexios/internal/store"
+)
+
+// fakeStore is a minimal in-memory store.Store used only by this test
+// file; the real pluggable backends live in internal/store/*.
+type fakeStore struct {
+	users    map[string]string // username -> userID
+	messages map[int64]store.Message
+	inbox    map[string][]int64
+	nextID   int64
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		users:    make(map[string]string),
+		messages: make(map[int64]store.Message),
+		inbox:    make(map[string][]int64),
+	}
+}
+
+func (f *fakeStore) FindRecepient(_ context.Context, username string) (string, error) {
+	userID, ok := f.users[username]
+	if !ok {
+		return "", store.ErrNotFound
+	}
+	return userID, nil
+}
+
+func (f *fakeStore) ListMessages(_ context.Context, userID string) ([]store.Message, error) {
+	var out []store.Message
+	for _, id := range f.inbox[userID] {
+		out = append(out, f.messages[id])
+	}
+	return out, nil
+}
+
+func (f *fakeStore) GetMessage(_ context.Context, id int64) (*store.Message, error) {
+	msg, ok := f.messages[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &msg, nil
+}
+
+func (f *fakeStore) SaveMessage(_ context.Context, userID string, msg store.Message) error {
+	f.nextID++
+	msg.ID = f.nextID
+	msg.Seq = f.nextID
+	f.messages[msg.ID] = msg
+	f.inbox[userID] = append(f.inbox[userID], msg.ID)
+	return nil
+}
+
+func (f *fakeStore) RegisterUser(_ context.Context, userID, username string) error {
+	if _, ok := f.users[username]; ok {
+		return store.ErrConflict
+	}
+	f.users[username] = userID
+	return nil
+}
+
+func (f *fakeStore) MarkRead(_ context.Context, id int64) error {
+	msg, ok := f.messages[id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	now := time.Now()
+	msg.ReadAt = &now
+	f.messages[id] = msg
+	return nil
+}
+
+func (f *fakeStore) ListMessagesPage(_ context.Context, userID string, opts store.ListOpts) ([]store.Message, string, error) {
+	var afterSeq int64
+	if opts.Cursor != "" {
+		parsed, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		afterSeq = parsed
+	}
+
+	var filtered []store.Message
+	for _, id := range f.inbox[userID] {
+		msg := f.messages[id]
+		if msg.Seq <= afterSeq {
+			continue
+		}
+		if opts.UnreadOnly && msg.ReadAt != nil {
+			continue
+		}
+		filtered = append(filtered, msg)
+	}
+
+	page := filtered
+	var nextCursor string
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		page = filtered[:opts.Limit]
+		nextCursor = strconv.FormatInt(page[len(page)-1].Seq, 10)
+	}
+
+	return page, nextCursor, nil
+}
+
+func (f *fakeStore) ListThread(_ context.Context, rootID int64) ([]store.Message, error) {
+	root, ok := f.messages[rootID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	thread := []store.Message{root}
+	for _, msg := range f.messages {
+		if msg.ReplyTo != nil && *msg.ReplyTo == rootID {
+			thread = append(thread, msg)
+		}
+	}
+	sort.Slice(thread[1:], func(i, j int) bool { return thread[1+i].Seq < thread[1+j].Seq })
+	return thread, nil
+}
+
+func TestServerLoginAndMessageFlow(t *testing.T) {
+	auth.Initialize("test-secret", time.Minute)
+
+	fs := newFakeStore()
+	if err := fs.RegisterUser(context.Background(), "alice-id", "alice"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+	if err := fs.RegisterUser(context.Background(), "bob-id", "bob"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+
+	srv := httptest.NewServer(NewServer(fs).Routes())
+	defer srv.Close()
+
+	loginBody, _ := json.Marshal(loginRequest{UserID: "alice-id", Username: "alice"})
+	resp, err := http.Post(srv.URL+"/login", "application/json", bytes.NewReader(loginBody))
+	if err != nil {
+		t.Fatalf("POST /login error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /login status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var login loginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		t.Fatalf("decode login response: %v", err)
+	}
+	resp.Body.Close()
+
+	sendBody, _ := json.Marshal(sendMessageRequest{Recipient: "bob", Payload: "hi bob"})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/messages", bytes.NewReader(sendBody))
+	req.Header.Set("Authorization", "Bearer "+login.Token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /messages error = %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /messages status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/messages", nil)
+	req.Header.Set("Authorization", "Bearer "+login.Token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /messages (as sender) error = %v", err)
+	}
+	resp.Body.Close()
+
+	bobLoginBody, _ := json.Marshal(loginRequest{UserID: "bob-id", Username: "bob"})
+	resp, err = http.Post(srv.URL+"/login", "application/json", bytes.NewReader(bobLoginBody))
+	if err != nil {
+		t.Fatalf("POST /login (bob) error = %v", err)
+	}
+	var bobLogin loginResponse
+	json.NewDecoder(resp.Body).Decode(&bobLogin)
+	resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/messages", nil)
+	req.Header.Set("Authorization", "Bearer "+bobLogin.Token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /messages (bob) error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var messages []store.Message
+	if err := json.NewDecoder(resp.Body).Decode(&messages); err != nil {
+		t.Fatalf("decode messages: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("len(messages) = %d, want 1", len(messages))
+	}
+	if messages[0].Payload != "hi bob" {
+		t.Errorf("messages[0].Payload = %q, want %q", messages[0].Payload, "hi bob")
+	}
+}
+
+func TestHandleMessagesRequiresAuth(t *testing.T) {
+	auth.Initialize("test-secret", time.Minute)
+
+	srv := httptest.NewServer(NewServer(newFakeStore()).Routes())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/messages")
+	if err != nil {
+		t.Fatalf("GET /messages error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestHandleMessagesStreamUnsupportedBackend(t *testing.T) {
+	auth.Initialize("test-secret", time.Minute)
+
+	fs := newFakeStore()
+	if err := fs.RegisterUser(context.Background(), "alice-id", "alice"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+
+	srv := httptest.NewServer(NewServer(fs).Routes())
+	defer srv.Close()
+
+	token, err := auth.GenerateToken("alice-id")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	for _, path := range []string{"/messages/stream", "/messages/ws"} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNotImplemented {
+			t.Errorf("GET %s status = %d, want %d", path, resp.StatusCode, http.StatusNotImplemented)
+		}
+	}
+}
+
+func TestHandleMessageByIDRejectsOtherUsersMessages(t *testing.T) {
+	auth.Initialize("test-secret", time.Minute)
+
+	fs := newFakeStore()
+	if err := fs.RegisterUser(context.Background(), "alice-id", "alice"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+	if err := fs.RegisterUser(context.Background(), "bob-id", "bob"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+	if err := fs.SaveMessage(context.Background(), "bob-id", store.Message{Sender: "alice-id", Time: time.Now(), Payload: "for bob only"}); err != nil {
+		t.Fatalf("SaveMessage() error = %v", err)
+	}
+
+	srv := httptest.NewServer(NewServer(fs).Routes())
+	defer srv.Close()
+
+	aliceToken, err := auth.GenerateToken("alice-id")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/messages/1", nil)
+	req.Header.Set("Authorization", "Bearer "+aliceToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /messages/1 (as alice) error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /messages/1 (as alice, owned by bob) status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	bobToken, err := auth.GenerateToken("bob-id")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/messages/1", nil)
+	req.Header.Set("Authorization", "Bearer "+bobToken)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /messages/1 (as bob) error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /messages/1 (as bob, owns it) status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
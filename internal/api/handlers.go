@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/auth"
+	"github.com/hairutdin/alexios/internal/logger"
+	"github.com/hairutdin/alexios/internal/store"
+)
+
+type loginRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+type loginResponse struct {
+	Token string `json:"token"`
+}
+
+// handleLogin trades an Alice UserID + the username it was registered
+// under for a bearer token usable against the rest of the API.
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.Username == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	resolvedID, err := s.store.FindRecepient(r.Context(), req.Username)
+	if err != nil || resolvedID != req.UserID {
+		logger.Log.Debug().Str("username", req.Username).Msg("login: username does not resolve to the given user id")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	token, err := auth.GenerateToken(req.UserID)
+	if err != nil {
+		logger.Log.Debug().Err(err).Msg("login: cannot generate token")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, loginResponse{Token: token})
+}
+
+type registerRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// handleRegister registers an Alice UserID under a username, the same
+// registration the "Sign Up" voice command performs.
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req registerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.Username == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := s.store.RegisterUser(r.Context(), req.UserID, req.Username)
+	if err != nil && !errors.Is(err, store.ErrConflict) {
+		logger.Log.Debug().Err(err).Msg("register: cannot register user")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if errors.Is(err, store.ErrConflict) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+type recipientResponse struct {
+	UserID string `json:"user_id"`
+}
+
+// handleFindRecipient resolves a username to the Alice UserID it is
+// registered under, GET /recipients/{username}.
+func (s *Server) handleFindRecipient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	username := strings.TrimPrefix(r.URL.Path, "/recipients/")
+	if username == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userID, err := s.store.FindRecepient(r.Context(), username)
+	if err != nil {
+		logger.Log.Debug().Str("username", username).Err(err).Msg("cannot find recepient by username")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, recipientResponse{UserID: userID})
+}
+
+type sendMessageRequest struct {
+	Recipient string `json:"recipient"`
+	Payload   string `json:"payload"`
+}
+
+// handleMessages serves GET /messages (the caller's inbox) and
+// POST /messages (send a message to a recipient).
+func (s *Server) handleMessages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserID(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		messages, _, err := s.store.ListMessagesPage(r.Context(), userID, store.ListOpts{UnreadOnly: true})
+		if err != nil {
+			logger.Log.Debug().Err(err).Msg("cannot load messages for user")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, messages)
+
+	case http.MethodPost:
+		var req sendMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Recipient == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recepientID, err := s.store.FindRecepient(r.Context(), req.Recipient)
+		if err != nil {
+			logger.Log.Debug().Str("username", req.Recipient).Err(err).Msg("cannot find recepient by username")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = s.store.SaveMessage(r.Context(), recepientID, store.Message{
+			Sender:  userID,
+			Time:    time.Now(),
+			Payload: req.Payload,
+		})
+		if err != nil {
+			logger.Log.Debug().Str("recepient", recepientID).Err(err).Msg("cannot save message")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMessageByID serves GET /messages/{id}.
+func (s *Server) handleMessageByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := auth.UserID(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/messages/"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	owned, err := s.ownsMessage(r.Context(), userID, id)
+	if err != nil {
+		logger.Log.Debug().Int64("id", id).Err(err).Msg("cannot load messages for user")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !owned {
+		// Same response as a genuinely unknown ID, so callers can't
+		// use this endpoint to probe which IDs belong to someone else.
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	message, err := s.store.GetMessage(r.Context(), id)
+	if err != nil {
+		logger.Log.Debug().Int64("id", id).Err(err).Msg("cannot load message")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, message)
+}
+
+// ownsMessage reports whether id is one of userID's own messages.
+// store.Message carries no recipient field (ListMessages et al. are
+// already scoped to the caller), so GetMessage alone can't tell;
+// ownership is checked by matching id against the caller's inbox
+// instead of widening the Store interface for a single endpoint.
+func (s *Server) ownsMessage(ctx context.Context, userID string, id int64) (bool, error) {
+	messages, err := s.store.ListMessages(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, msg := range messages {
+		if msg.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
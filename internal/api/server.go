@@ -0,0 +1,37 @@
+// Package api exposes the same store.Store the Alice webhook uses over
+// a JWT-authenticated REST API, so external clients (mobile apps, CLIs)
+// can send and read messages without going through a voice session.
+package api
+
+import (
+	"net/http"
+
+	"github.com/hairutdin/alexios/internal/auth"
+	"github.com/hairutdin/alexios/internal/store"
+)
+
+// Server adapts a store.Store to HTTP handlers.
+type Server struct {
+	store store.Store
+}
+
+// NewServer returns a Server backed by s.
+func NewServer(s store.Store) *Server {
+	return &Server{store: s}
+}
+
+// Routes returns the handler to mount the API on, e.g. via
+// http.ListenAndServe(addr, server.Routes()).
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", s.handleLogin)
+	mux.HandleFunc("/register", s.handleRegister)
+	mux.HandleFunc("/recipients/", auth.Middleware(s.handleFindRecipient))
+	mux.HandleFunc("/messages", auth.Middleware(s.handleMessages))
+	mux.HandleFunc("/messages/stream", auth.Middleware(s.handleMessagesStream))
+	mux.HandleFunc("/messages/ws", auth.Middleware(s.handleMessagesWS))
+	mux.HandleFunc("/messages/", auth.Middleware(s.handleMessageByID))
+
+	return mux
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/auth"
+	"github.com/hairutdin/alexios/internal/logger"
+	"github.com/hairutdin/alexios/internal/store"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+const (
+	defaultStreamTimeout = 25 * time.Second
+	maxStreamTimeout     = 60 * time.Second
+)
+
+// handleMessagesStream serves GET /messages/stream: a long-poll that
+// blocks until at least one message arrives for the caller, or timeout
+// elapses, whichever comes first. Backends that don't implement
+// store.Notifier respond 501, since there is nothing to long-poll on.
+func (s *Server) handleMessagesStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := auth.UserID(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	notifier, ok := s.store.(store.Notifier)
+	if !ok {
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+
+	timeout := defaultStreamTimeout
+	if raw := r.URL.Query().Get("timeout"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		timeout = parsed
+	}
+	if timeout > maxStreamTimeout {
+		timeout = maxStreamTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	msgs, err := notifier.Subscribe(ctx, userID)
+	if err != nil {
+		logger.Log.Debug().Str("user_id", userID).Err(err).Msg("stream: cannot subscribe to notifications")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	collected := waitForMessages(ctx, msgs)
+	writeJSON(w, http.StatusOK, collected)
+}
+
+// waitForMessages blocks for the first message on msgs (or until ctx is
+// done), then drains whatever else has already arrived without
+// blocking further, so a long-poll response reports everything saved
+// during the wait rather than just the first message.
+func waitForMessages(ctx context.Context, msgs <-chan store.Message) []store.Message {
+	collected := []store.Message{}
+
+	select {
+	case msg, open := <-msgs:
+		if open {
+			collected = append(collected, msg)
+		}
+	case <-ctx.Done():
+		return collected
+	}
+
+	for {
+		select {
+		case msg, open := <-msgs:
+			if !open {
+				return collected
+			}
+			collected = append(collected, msg)
+		default:
+			return collected
+		}
+	}
+}
+
+// handleMessagesWS serves GET /messages/ws: upgrades to a WebSocket and
+// pushes every message saved for the caller as a JSON frame, until the
+// client disconnects. Backends that don't implement store.Notifier
+// respond 501.
+func (s *Server) handleMessagesWS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := auth.UserID(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	notifier, ok := s.store.(store.Notifier)
+	if !ok {
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		logger.Log.Debug().Str("user_id", userID).Err(err).Msg("ws: cannot accept connection")
+		return
+	}
+	defer conn.CloseNow()
+
+	// The connection is push-only from our side; CloseRead discards
+	// anything the client sends and cancels ctx once the client closes.
+	ctx := conn.CloseRead(r.Context())
+
+	msgs, err := notifier.Subscribe(ctx, userID)
+	if err != nil {
+		logger.Log.Debug().Str("user_id", userID).Err(err).Msg("ws: cannot subscribe to notifications")
+		conn.Close(websocket.StatusInternalError, "subscribe failed")
+		return
+	}
+
+	for {
+		select {
+		case msg, open := <-msgs:
+			if !open {
+				conn.Close(websocket.StatusNormalClosure, "")
+				return
+			}
+			if err := wsjson.Write(ctx, conn, msg); err != nil {
+				logger.Log.Debug().Str("user_id", userID).Err(err).Msg("ws: cannot write message frame")
+				return
+			}
+		case <-ctx.Done():
+			conn.Close(websocket.StatusNormalClosure, "")
+			return
+		}
+	}
+}
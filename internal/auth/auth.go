@@ -0,0 +1,67 @@
+// Package auth issues and validates the HS256 JWT bearer tokens used by
+// the REST API. The Alice webhook doesn't need it (Alice authenticates
+// sessions itself), but any external client talking to internal/api does.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned by ParseToken for a token that is
+// malformed, expired, or signed with an unexpected method or key.
+var ErrInvalidToken = errors.New("auth: invalid or expired token")
+
+var (
+	signingKey []byte
+	tokenTTL   time.Duration
+)
+
+// Initialize configures the package with the signing secret and token
+// TTL to use for GenerateToken and ParseToken. It must be called once
+// at startup, before either is used.
+func Initialize(secret string, ttl time.Duration) {
+	signingKey = []byte(secret)
+	tokenTTL = ttl
+}
+
+// Claims are the JWT claims embedded in an API access token.
+type Claims struct {
+	UserID string `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken issues an HS256-signed access token for userID, the
+// Alice session UserID that identifies the account in store.Store.
+func GenerateToken(userID string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(signingKey)
+}
+
+// ParseToken validates tokenString and returns its claims.
+func ParseToken(tokenString string) (Claims, error) {
+	var claims Claims
+
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return signingKey, nil
+	})
+	if err != nil || !token.Valid {
+		return Claims{}, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestGenerateAndParseToken(t *testing.T) {
+	Initialize("test-secret", time.Hour)
+
+	token, err := GenerateToken("user-1")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	claims, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if claims.UserID != "user-1" {
+		t.Errorf("claims.UserID = %q, want %q", claims.UserID, "user-1")
+	}
+}
+
+func TestParseTokenExpired(t *testing.T) {
+	Initialize("test-secret", -time.Minute)
+
+	token, err := GenerateToken("user-1")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	if _, err := ParseToken(token); err != ErrInvalidToken {
+		t.Fatalf("ParseToken() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestParseTokenWrongSecret(t *testing.T) {
+	Initialize("test-secret", time.Hour)
+	token, err := GenerateToken("user-1")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	Initialize("a-different-secret", time.Hour)
+	if _, err := ParseToken(token); err != ErrInvalidToken {
+		t.Fatalf("ParseToken() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestParseTokenRejectsUnexpectedSigningMethod(t *testing.T) {
+	Initialize("test-secret", time.Hour)
+
+	claims := Claims{
+		UserID: "user-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	// alg "none" is the classic JWT signature-bypass attack: a server
+	// that doesn't pin the expected signing method will accept an
+	// unsigned token as valid.
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("sign alg=none token: %v", err)
+	}
+
+	if _, err := ParseToken(token); err != ErrInvalidToken {
+		t.Fatalf("ParseToken() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestParseTokenRejectsMalformedToken(t *testing.T) {
+	Initialize("test-secret", time.Hour)
+
+	if _, err := ParseToken("not.a.token"); err != ErrInvalidToken {
+		t.Fatalf("ParseToken() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
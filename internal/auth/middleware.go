@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey int
+
+const userIDContextKey contextKey = 0
+
+// Middleware requires a valid "Authorization: Bearer <token>" header on
+// every request, and populates ctx with the authenticated user ID so
+// downstream handlers can read it with UserID.
+func Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		tokenString, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || tokenString == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := ParseToken(tokenString)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userIDContextKey, claims.UserID)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// UserID returns the authenticated user ID stored in ctx by Middleware.
+func UserID(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(string)
+	return userID, ok
+}
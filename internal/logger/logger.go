@@ -0,0 +1,105 @@
+// Package logger provides the skill's structured logger: a thin
+// chainable event API wrapping zap (`Log.Info().Str("k", v).Msg(...)`),
+// so call sites build up one structured line per log statement instead
+// of juggling a message string and a slice of zap.Field.
+package logger
+
+import (
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log is the package-level logger, ready to use after Initialize.
+var Log *Logger
+
+// Logger is a small fluent wrapper around *zap.Logger.
+type Logger struct {
+	zl *zap.Logger
+}
+
+// Initialize configures Log to write at level ("debug", "info", ...)
+// in the given format ("json" or "console").
+func Initialize(level, format string) error {
+	zapLevel, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+
+	var encoder zapcore.Encoder
+	if format == "console" {
+		encoderCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	} else {
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), zapLevel)
+	Log = &Logger{zl: zap.New(core)}
+	return nil
+}
+
+// Debug starts a debug-level event.
+func (l *Logger) Debug() *Event { return newEvent(l, zapcore.DebugLevel) }
+
+// Info starts an info-level event.
+func (l *Logger) Info() *Event { return newEvent(l, zapcore.InfoLevel) }
+
+// Warn starts a warn-level event.
+func (l *Logger) Warn() *Event { return newEvent(l, zapcore.WarnLevel) }
+
+// Error starts an error-level event.
+func (l *Logger) Error() *Event { return newEvent(l, zapcore.ErrorLevel) }
+
+// Event accumulates fields for a single log line before Msg flushes it.
+type Event struct {
+	logger *Logger
+	level  zapcore.Level
+	fields []zap.Field
+}
+
+func newEvent(l *Logger, level zapcore.Level) *Event {
+	return &Event{logger: l, level: level}
+}
+
+// Str attaches a string field.
+func (e *Event) Str(key, value string) *Event {
+	e.fields = append(e.fields, zap.String(key, value))
+	return e
+}
+
+// Int64 attaches an int64 field.
+func (e *Event) Int64(key string, value int64) *Event {
+	e.fields = append(e.fields, zap.Int64(key, value))
+	return e
+}
+
+// Int attaches an int field.
+func (e *Event) Int(key string, value int) *Event {
+	e.fields = append(e.fields, zap.Int(key, value))
+	return e
+}
+
+// Dur attaches a duration field.
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	e.fields = append(e.fields, zap.Duration(key, value))
+	return e
+}
+
+// Err attaches the error under the conventional "error" key.
+func (e *Event) Err(err error) *Event {
+	e.fields = append(e.fields, zap.Error(err))
+	return e
+}
+
+// Msg flushes the event as a single structured log line.
+func (e *Event) Msg(msg string) {
+	if ce := e.logger.zl.Check(e.level, msg); ce != nil {
+		ce.Write(e.fields...)
+	}
+}
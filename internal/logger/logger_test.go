@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObservedLogger(level zapcore.Level) (*Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return &Logger{zl: zap.New(core)}, logs
+}
+
+func TestEventChaining(t *testing.T) {
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	l.Info().
+		Str("handler", "webhook").
+		Str("intent", "send_message").
+		Int64("user_count", 3).
+		Dur("latency", 2*time.Millisecond).
+		Err(errors.New("boom")).
+		Msg("handled phase")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("len(entries) = %d, want 1", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Message != "handled phase" {
+		t.Errorf("Message = %q, want %q", entry.Message, "handled phase")
+	}
+	if entry.Level != zapcore.InfoLevel {
+		t.Errorf("Level = %v, want %v", entry.Level, zapcore.InfoLevel)
+	}
+
+	fields := entry.ContextMap()
+	if fields["handler"] != "webhook" {
+		t.Errorf("handler field = %v, want %q", fields["handler"], "webhook")
+	}
+	if fields["intent"] != "send_message" {
+		t.Errorf("intent field = %v, want %q", fields["intent"], "send_message")
+	}
+	if fields["error"] != "boom" {
+		t.Errorf("error field = %v, want %q", fields["error"], "boom")
+	}
+}
+
+func TestEventRespectsLevel(t *testing.T) {
+	l, logs := newObservedLogger(zapcore.InfoLevel)
+
+	l.Debug().Str("k", "v").Msg("should be filtered out")
+
+	if len(logs.All()) != 0 {
+		t.Fatalf("len(entries) = %d, want 0 (debug below the observer's info level)", len(logs.All()))
+	}
+}
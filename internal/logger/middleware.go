@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey int
+
+const requestIDKey contextKey = 0
+
+// RequestID returns the UUID RequestLogger assigned to this request,
+// or "" if ctx didn't go through that middleware.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *loggingResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// RequestLogger injects a UUID request ID into the request context (so
+// every log line emitted while handling it can carry the same
+// "request_id", letting them be correlated) and logs one summary line
+// per request with its method, URI, status, and latency.
+func RequestLogger(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := uuid.NewString()
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey, requestID))
+
+		lw := &loggingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		h(lw, r)
+
+		Log.Info().
+			Str("request_id", requestID).
+			Str("method", r.Method).
+			Str("uri", r.RequestURI).
+			Int("status", lw.status).
+			Dur("duration", time.Since(start)).
+			Msg("handled request")
+	}
+}
@@ -0,0 +1,73 @@
+package nlu
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// dictionary holds the trigger words and ordinal words for a single
+// locale, as loaded from internal/nlu/locales/<locale>.json.
+type dictionary struct {
+	locale   string
+	Send     []string       `json:"send"`
+	Read     []string       `json:"read"`
+	Register []string       `json:"register"`
+	List     []string       `json:"list"`
+	Ordinals map[string]int `json:"ordinals"`
+}
+
+func loadDictionary(locale string) (*dictionary, error) {
+	data, err := localeFS.ReadFile(fmt.Sprintf("locales/%s.json", locale))
+	if err != nil {
+		return nil, fmt.Errorf("nlu: unknown locale %q: %w", locale, err)
+	}
+
+	d := &dictionary{locale: locale}
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, fmt.Errorf("nlu: malformed locale %q: %w", locale, err)
+	}
+
+	return d, nil
+}
+
+// triggersFor returns the trigger word list for the given intent.
+func (d *dictionary) triggersFor(intent Intent) []string {
+	switch intent {
+	case IntentSendMessage:
+		return d.Send
+	case IntentReadMessage:
+		return d.Read
+	case IntentRegister:
+		return d.Register
+	case IntentListMessages:
+		return d.List
+	default:
+		return nil
+	}
+}
+
+// matches reports whether token triggers intent in this locale, either
+// exactly or within the fuzzy-match threshold for its length.
+func (d *dictionary) matches(token string, intent Intent) bool {
+	for _, trigger := range d.triggersFor(intent) {
+		if token == trigger {
+			return true
+		}
+		if levenshtein(token, trigger) <= fuzzyMatchThreshold(trigger) {
+			return true
+		}
+	}
+	return false
+}
+
+// ordinal converts an ordinal word ("first", "второе", ...) to its
+// 1-based integer value, or to IndexLast for "last"/"последнее". ok is
+// false if the word isn't a known ordinal.
+func (d *dictionary) ordinal(token string) (n int, ok bool) {
+	n, ok = d.Ordinals[token]
+	return n, ok
+}
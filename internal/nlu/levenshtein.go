@@ -0,0 +1,61 @@
+package nlu
+
+// levenshtein returns the edit distance between a and b, used as a
+// fallback when a token doesn't exactly match any known trigger word
+// (e.g. Alice mis-hearing "отправь" as "отправ" or "send" as "sent").
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+
+	if la == 0 {
+		return lb
+	}
+	if lb == 0 {
+		return la
+	}
+
+	prev := make([]int, lb+1)
+	curr := make([]int, lb+1)
+	for j := 0; j <= lb; j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		curr[0] = i
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[lb]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// fuzzyMatchThreshold returns the maximum edit distance tolerated for a
+// word of the given length before it's considered a non-match. Short
+// words need an exact (or near-exact) match to avoid false positives.
+func fuzzyMatchThreshold(word string) int {
+	switch n := len([]rune(word)); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,196 @@
+// Package nlu parses the free-form text Alice/Alexios hands to the
+// skill into a structured Intent plus the slots needed to act on it.
+// Unlike naive strings.HasPrefix matching, it understands multiple
+// locales, tolerates punctuation and misheard words, and extracts
+// ordinals ("прочитай второе сообщение") rather than requiring a raw
+// numeric index.
+package nlu
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Intent identifies what the user is asking the skill to do.
+type Intent string
+
+const (
+	IntentSendMessage  Intent = "send_message"
+	IntentReadMessage  Intent = "read_message"
+	IntentRegister     Intent = "register"
+	IntentListMessages Intent = "list_messages"
+	IntentUnknown      Intent = "unknown"
+)
+
+// IndexLast is the Slots.Index value for "read the last/most recent
+// message" ("прочитай последнее сообщение"), resolved relative to the
+// caller's message count rather than a fixed position.
+const IndexLast = -2
+
+// Slots carries the values extracted from the command text. Which
+// fields are populated depends on Intent: Recipient/Message for
+// IntentSendMessage, Index for IntentReadMessage, Username for
+// IntentRegister.
+type Slots struct {
+	Recipient string
+	Message   string
+	Username  string
+	// Index is the zero-based message index, IndexLast for "the most
+	// recent message", or -1 if the command didn't name one. A read
+	// trigger with nothing after it ("read") yields 0, the first message.
+	Index int
+}
+
+// Result is the outcome of parsing a single command.
+type Result struct {
+	Intent Intent
+	Slots  Slots
+}
+
+// Parser turns raw command text into a Result.
+type Parser interface {
+	Parse(command string) Result
+}
+
+// defaultLocales are loaded by NewParser when no locale is given.
+var defaultLocales = []string{"en", "ru"}
+
+// multiParser is a Parser backed by one dictionary per supported
+// locale. Commands aren't tagged with a locale by Alice, so it tries
+// every loaded dictionary and takes the first intent match.
+type multiParser struct {
+	dictionaries []*dictionary
+}
+
+// NewParser builds a Parser that recognizes commands in the given
+// locales (e.g. "en", "ru"). With no locales given, it loads
+// defaultLocales.
+func NewParser(locales ...string) (Parser, error) {
+	if len(locales) == 0 {
+		locales = defaultLocales
+	}
+
+	p := &multiParser{}
+	for _, locale := range locales {
+		d, err := loadDictionary(locale)
+		if err != nil {
+			return nil, err
+		}
+		p.dictionaries = append(p.dictionaries, d)
+	}
+
+	return p, nil
+}
+
+func (p *multiParser) Parse(command string) Result {
+	tokens, rawTokens := tokenize(command)
+	slots := Slots{Index: -1}
+
+	if len(tokens) == 0 {
+		return Result{Intent: IntentUnknown, Slots: slots}
+	}
+
+	for _, intent := range []Intent{IntentRegister, IntentSendMessage, IntentReadMessage, IntentListMessages} {
+		d, pos, ok := p.findTrigger(tokens, intent)
+		if !ok {
+			continue
+		}
+
+		switch intent {
+		case IntentSendMessage:
+			slots.Recipient, slots.Message = splitSendSlots(rawTokens, pos)
+		case IntentReadMessage:
+			slots.Index = extractIndex(tokens, pos, d)
+		case IntentRegister:
+			slots.Username = lastToken(rawTokens)
+		}
+
+		return Result{Intent: intent, Slots: slots}
+	}
+
+	return Result{Intent: IntentUnknown, Slots: slots}
+}
+
+// findTrigger looks for a word in tokens that triggers intent in any
+// loaded dictionary, returning that dictionary and the token's index.
+func (p *multiParser) findTrigger(tokens []string, intent Intent) (*dictionary, int, bool) {
+	for _, d := range p.dictionaries {
+		for i, tok := range tokens {
+			if d.matches(tok, intent) {
+				return d, i, true
+			}
+		}
+	}
+	return nil, -1, false
+}
+
+// splitSendSlots extracts the recipient and message text that follow
+// the send trigger word, e.g. "send John hello there" -> ("John",
+// "hello there").
+func splitSendSlots(tokens []string, triggerPos int) (recipient, message string) {
+	rest := tokens[triggerPos+1:]
+	if len(rest) == 0 {
+		return "", ""
+	}
+	if len(rest) == 1 {
+		return rest[0], ""
+	}
+	return rest[0], strings.Join(rest[1:], " ")
+}
+
+// extractIndex resolves the message index following a read trigger:
+// a digit ("read 2"), an ordinal word ("read second"), the special
+// "last" ordinal ("read the last message"), or an implicit first
+// message ("read" with nothing after it).
+func extractIndex(tokens []string, triggerPos int, d *dictionary) int {
+	rest := tokens[triggerPos+1:]
+	if len(rest) == 0 {
+		return 0
+	}
+
+	for _, tok := range rest {
+		if n, err := strconv.Atoi(tok); err == nil && n > 0 {
+			return n - 1
+		}
+		if n, ok := d.ordinal(tok); ok {
+			if n == IndexLast {
+				return IndexLast
+			}
+			if n > 0 {
+				return n - 1
+			}
+		}
+	}
+
+	return -1
+}
+
+// lastToken returns the final token, used for the username in
+// "sign up JohnDoe" / "зарегистрируй JohnDoe".
+func lastToken(tokens []string) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return tokens[len(tokens)-1]
+}
+
+// tokenize strips punctuation and splits the command on whitespace,
+// returning two aligned token slices: lower, for trigger-word and
+// ordinal matching, and raw, preserving the original case for slots
+// (Recipient/Username) that get compared against data stored elsewhere
+// without case normalization.
+func tokenize(command string) (lower, raw []string) {
+	var lowerB, rawB strings.Builder
+	for _, r := range command {
+		switch {
+		case r == ',' || r == '.' || r == '!' || r == '?' || r == ';' || r == ':':
+			lowerB.WriteRune(' ')
+			rawB.WriteRune(' ')
+		default:
+			lowerB.WriteRune(unicode.ToLower(r))
+			rawB.WriteRune(r)
+		}
+	}
+	return strings.Fields(lowerB.String()), strings.Fields(rawB.String())
+}
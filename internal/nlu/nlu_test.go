@@ -0,0 +1,139 @@
+package nlu
+
+import "testing"
+
+func mustParser(t *testing.T) Parser {
+	t.Helper()
+	p, err := NewParser()
+	if err != nil {
+		t.Fatalf("NewParser() error = %v", err)
+	}
+	return p
+}
+
+func TestParse(t *testing.T) {
+	p := mustParser(t)
+
+	tests := []struct {
+		name       string
+		command    string
+		wantIntent Intent
+		wantSlots  Slots
+	}{
+		{
+			name:       "english send",
+			command:    "Send John Hello, how are you?",
+			wantIntent: IntentSendMessage,
+			wantSlots:  Slots{Recipient: "John", Message: "Hello how are you", Index: -1},
+		},
+		{
+			name:       "russian send",
+			command:    "отправь Ивану привет",
+			wantIntent: IntentSendMessage,
+			wantSlots:  Slots{Recipient: "Ивану", Message: "привет", Index: -1},
+		},
+		{
+			name:       "english read by number",
+			command:    "Read 1",
+			wantIntent: IntentReadMessage,
+			wantSlots:  Slots{Index: 0},
+		},
+		{
+			name:       "english read by ordinal",
+			command:    "read the second message",
+			wantIntent: IntentReadMessage,
+			wantSlots:  Slots{Index: 1},
+		},
+		{
+			name:       "russian read by ordinal",
+			command:    "прочитай второе сообщение",
+			wantIntent: IntentReadMessage,
+			wantSlots:  Slots{Index: 1},
+		},
+		{
+			name:       "russian read first",
+			command:    "прочитай первое",
+			wantIntent: IntentReadMessage,
+			wantSlots:  Slots{Index: 0},
+		},
+		{
+			name:       "english read last",
+			command:    "read the last message",
+			wantIntent: IntentReadMessage,
+			wantSlots:  Slots{Index: IndexLast},
+		},
+		{
+			name:       "russian read last",
+			command:    "прочитай последнее сообщение",
+			wantIntent: IntentReadMessage,
+			wantSlots:  Slots{Index: IndexLast},
+		},
+		{
+			name:       "register",
+			command:    "Sign Up JohnDoe",
+			wantIntent: IntentRegister,
+			wantSlots:  Slots{Username: "JohnDoe", Index: -1},
+		},
+		{
+			name:       "russian register",
+			command:    "зарегистрируй JohnDoe",
+			wantIntent: IntentRegister,
+			wantSlots:  Slots{Username: "JohnDoe", Index: -1},
+		},
+		{
+			name:       "list messages",
+			command:    "list messages",
+			wantIntent: IntentListMessages,
+			wantSlots:  Slots{Index: -1},
+		},
+		{
+			name:       "misheard send is still recognized",
+			command:    "sent John hi",
+			wantIntent: IntentSendMessage,
+			wantSlots:  Slots{Recipient: "John", Message: "hi", Index: -1},
+		},
+		{
+			name:       "unknown command",
+			command:    "what time is it",
+			wantIntent: IntentUnknown,
+			wantSlots:  Slots{Index: -1},
+		},
+		{
+			name:       "empty command",
+			command:    "",
+			wantIntent: IntentUnknown,
+			wantSlots:  Slots{Index: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.Parse(tt.command)
+			if got.Intent != tt.wantIntent {
+				t.Errorf("Parse(%q).Intent = %v, want %v", tt.command, got.Intent, tt.wantIntent)
+			}
+			if got.Slots != tt.wantSlots {
+				t.Errorf("Parse(%q).Slots = %+v, want %+v", tt.command, got.Slots, tt.wantSlots)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"send", "send", 0},
+		{"send", "sned", 2},
+		{"send", "sent", 1},
+		{"привет", "превет", 1},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Package memory is a map-based store.Store backend for tests and
+// local development without a database.
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+)
+
+func init() {
+	store.Register("memory", func(_ string) (store.Store, error) {
+		return NewStore(), nil
+	})
+}
+
+// Store is an in-process, in-memory store.Store. It is safe for
+// concurrent use but holds no state beyond the process lifetime.
+type Store struct {
+	mu          sync.Mutex
+	usersByID   map[string]string // userID -> username
+	usersByUsr  map[string]string // username -> userID
+	messages    map[int64]store.Message
+	inbox       map[string][]int64 // userID -> message IDs, in send order
+	nextID      int64
+	subscribers map[string][]chan store.Message // userID -> live Subscribe channels
+}
+
+// subscriberBuffer bounds how many unread pushes a slow Subscribe
+// caller can fall behind by before further messages are dropped for
+// it; ListMessages remains the source of truth regardless.
+const subscriberBuffer = 16
+
+// NewStore returns an empty in-memory Store.
+func NewStore() *Store {
+	return &Store{
+		usersByID:   make(map[string]string),
+		usersByUsr:  make(map[string]string),
+		messages:    make(map[int64]store.Message),
+		inbox:       make(map[string][]int64),
+		subscribers: make(map[string][]chan store.Message),
+	}
+}
+
+func (s *Store) RegisterUser(_ context.Context, userID, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, taken := s.usersByUsr[username]; taken {
+		return store.ErrConflict
+	}
+
+	s.usersByID[userID] = username
+	s.usersByUsr[username] = userID
+	return nil
+}
+
+func (s *Store) FindRecepient(_ context.Context, username string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, ok := s.usersByUsr[username]
+	if !ok {
+		return "", store.ErrNotFound
+	}
+	return userID, nil
+}
+
+func (s *Store) SaveMessage(_ context.Context, userID string, msg store.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	msg.ID = s.nextID
+	msg.Seq = s.nextID
+	s.messages[msg.ID] = msg
+	s.inbox[userID] = append(s.inbox[userID], msg.ID)
+
+	// Sends are non-blocking, so they are made under the lock; Subscribe
+	// closes a channel under the same lock, so none is sent to once closed.
+	for _, ch := range s.subscribers[userID] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe implements store.Notifier with in-process pub-sub: the
+// returned channel receives every message SaveMessage saves for userID
+// until ctx is cancelled.
+func (s *Store) Subscribe(ctx context.Context, userID string) (<-chan store.Message, error) {
+	ch := make(chan store.Message, subscriberBuffer)
+
+	s.mu.Lock()
+	s.subscribers[userID] = append(s.subscribers[userID], ch)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		subs := s.subscribers[userID]
+		for i, c := range subs {
+			if c == ch {
+				s.subscribers[userID] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func (s *Store) MarkRead(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, ok := s.messages[id]
+	if !ok {
+		return store.ErrNotFound
+	}
+
+	now := time.Now()
+	msg.ReadAt = &now
+	s.messages[id] = msg
+	return nil
+}
+
+func (s *Store) ListMessagesPage(_ context.Context, userID string, opts store.ListOpts) ([]store.Message, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var afterSeq int64
+	if opts.Cursor != "" {
+		parsed, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		afterSeq = parsed
+	}
+
+	ids := append([]int64(nil), s.inbox[userID]...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var filtered []store.Message
+	for _, id := range ids {
+		msg := s.messages[id]
+		if msg.Seq <= afterSeq {
+			continue
+		}
+		if opts.UnreadOnly && msg.ReadAt != nil {
+			continue
+		}
+		if !opts.Since.IsZero() && msg.Time.Before(opts.Since) {
+			continue
+		}
+		filtered = append(filtered, msg)
+	}
+
+	page := filtered
+	var nextCursor string
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		page = filtered[:opts.Limit]
+		nextCursor = strconv.FormatInt(page[len(page)-1].Seq, 10)
+	}
+
+	return page, nextCursor, nil
+}
+
+func (s *Store) ListThread(_ context.Context, rootID int64) ([]store.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	root, ok := s.messages[rootID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	thread := []store.Message{root}
+	for _, msg := range s.messages {
+		if msg.ReplyTo != nil && *msg.ReplyTo == rootID {
+			thread = append(thread, msg)
+		}
+	}
+
+	sort.Slice(thread, func(i, j int) bool { return thread[i].Seq < thread[j].Seq })
+	return thread, nil
+}
+
+func (s *Store) ListMessages(_ context.Context, userID string) ([]store.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.inbox[userID]
+	messages := make([]store.Message, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, s.messages[id])
+	}
+	return messages, nil
+}
+
+func (s *Store) GetMessage(_ context.Context, id int64) (*store.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, ok := s.messages[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &msg, nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+	"github.com/hairutdin/alexios/internal/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		return NewStore()
+	})
+}
+
+func TestStoreSubscribe(t *testing.T) {
+	s := NewStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs, err := s.Subscribe(ctx, "recipient-1")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := s.SaveMessage(context.Background(), "recipient-1", store.Message{
+		Sender:  "sender-1",
+		Time:    time.Now(),
+		Payload: "pushed",
+	}); err != nil {
+		t.Fatalf("SaveMessage() error = %v", err)
+	}
+
+	select {
+	case msg := <-msgs:
+		if msg.Payload != "pushed" {
+			t.Errorf("msg.Payload = %q, want %q", msg.Payload, "pushed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for subscribed message")
+	}
+
+	cancel()
+
+	select {
+	case _, open := <-msgs:
+		if open {
+			t.Fatal("channel should be closed once the subscription's ctx is cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
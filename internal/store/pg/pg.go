@@ -0,0 +1,335 @@
+// Package pg is the Postgres store.Store backend.
+package pg
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+func init() {
+	store.Register("pg", func(dsn string) (store.Store, error) {
+		return Open(dsn)
+	})
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS users (
+	user_id  TEXT PRIMARY KEY,
+	username TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id           BIGSERIAL PRIMARY KEY,
+	sender       TEXT NOT NULL,
+	recipient_id TEXT NOT NULL,
+	sent_at      TIMESTAMPTZ NOT NULL,
+	payload      TEXT NOT NULL,
+	read_at      TIMESTAMPTZ NULL,
+	reply_to     BIGINT NULL REFERENCES messages (id)
+);
+
+CREATE INDEX IF NOT EXISTS messages_recipient_id_idx ON messages (recipient_id);
+CREATE INDEX IF NOT EXISTS messages_reply_to_idx ON messages (reply_to);
+`
+
+const messageColumns = `id, sender, sent_at, payload, read_at, reply_to`
+
+// Store is the Postgres-backed store.Store implementation.
+type Store struct {
+	conn *sql.DB
+}
+
+// NewStore wraps an already-open *sql.DB as a Store. Callers that
+// manage their own connection pool (as cmd/skill historically did)
+// can keep using this; new code should prefer Open.
+func NewStore(conn *sql.DB) *Store {
+	return &Store{conn: conn}
+}
+
+// Open connects to dsn with the pgx driver and ensures the schema
+// exists.
+func Open(dsn string) (*Store, error) {
+	conn, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.Exec(schema); err != nil {
+		return nil, err
+	}
+
+	return NewStore(conn), nil
+}
+
+func (s *Store) RegisterUser(ctx context.Context, userID, username string) error {
+	_, err := s.conn.ExecContext(ctx,
+		`INSERT INTO users (user_id, username) VALUES ($1, $2)`, userID, username)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return store.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Store) FindRecepient(ctx context.Context, username string) (string, error) {
+	var userID string
+	err := s.conn.QueryRowContext(ctx,
+		`SELECT user_id FROM users WHERE username = $1`, username).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+func (s *Store) SaveMessage(ctx context.Context, userID string, msg store.Message) error {
+	var replyTo sql.NullInt64
+	if msg.ReplyTo != nil {
+		replyTo = sql.NullInt64{Int64: *msg.ReplyTo, Valid: true}
+	}
+
+	if err := s.conn.QueryRowContext(ctx,
+		`INSERT INTO messages (sender, recipient_id, sent_at, payload, reply_to) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		msg.Sender, userID, msg.Time, msg.Payload, replyTo,
+	).Scan(&msg.ID); err != nil {
+		return err
+	}
+
+	_, err := s.conn.ExecContext(ctx, `SELECT pg_notify($1, $2)`, notifyChannel(userID), strconv.FormatInt(msg.ID, 10))
+	return err
+}
+
+// Subscribe implements store.Notifier via Postgres LISTEN/NOTIFY: it
+// holds a dedicated connection (pulled out of the pool, since LISTEN is
+// session-scoped) listening on userID's notify channel, and resolves
+// each notification's message ID to a full Message via GetMessage.
+func (s *Store) Subscribe(ctx context.Context, userID string) (<-chan store.Message, error) {
+	sqlConn, err := s.conn.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pgConn *pgx.Conn
+	if err := sqlConn.Raw(func(driverConn any) error {
+		pgConn = driverConn.(*stdlib.Conn).Conn()
+		return nil
+	}); err != nil {
+		sqlConn.Close()
+		return nil, err
+	}
+
+	channel := notifyChannel(userID)
+	if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		sqlConn.Close()
+		return nil, err
+	}
+
+	out := make(chan store.Message, 16)
+	go func() {
+		defer close(out)
+		defer func() {
+			// ctx is typically already cancelled by the time we get
+			// here, so UNLISTEN on its own detached context; otherwise
+			// the conn goes back to the pool still registered for this
+			// channel and every later query on it would start seeing
+			// unrelated NOTIFY payloads.
+			unlistenCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := pgConn.Exec(unlistenCtx, "UNLISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+				// UNLISTEN itself failing means the connection is
+				// probably unhealthy; sql.Conn.Close discards rather
+				// than pools a connection it can tell is broken.
+				pgConn.Close(unlistenCtx)
+			}
+			sqlConn.Close()
+		}()
+
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				// ctx cancelled, or the connection was lost.
+				return
+			}
+
+			id, err := strconv.ParseInt(notification.Payload, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			msg, err := s.GetMessage(ctx, id)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case out <- *msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// notifyChannel derives a Postgres channel name for userID's
+// notifications. userIDs come from Alice and aren't guaranteed to be
+// valid SQL identifiers on their own, so the channel name is a hash of
+// it instead.
+func notifyChannel(userID string) string {
+	return fmt.Sprintf("messages_%x", sha1.Sum([]byte(userID)))
+}
+
+func (s *Store) ListMessages(ctx context.Context, userID string) ([]store.Message, error) {
+	rows, err := s.conn.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM messages WHERE recipient_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+func (s *Store) GetMessage(ctx context.Context, id int64) (*store.Message, error) {
+	row := s.conn.QueryRowContext(ctx,
+		`SELECT `+messageColumns+` FROM messages WHERE id = $1`, id)
+	msg, err := scanMessage(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *Store) MarkRead(ctx context.Context, id int64) error {
+	res, err := s.conn.ExecContext(ctx, `UPDATE messages SET read_at = now() WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) ListMessagesPage(ctx context.Context, userID string, opts store.ListOpts) ([]store.Message, string, error) {
+	var afterSeq int64
+	if opts.Cursor != "" {
+		parsed, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		afterSeq = parsed
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = -1 // no LIMIT clause restriction below
+	}
+
+	rows, err := s.conn.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM messages
+		 WHERE recipient_id = $1 AND id > $2
+		   AND ($3::boolean IS FALSE OR read_at IS NULL)
+		   AND ($4::timestamptz IS NULL OR sent_at >= $4)
+		 ORDER BY id
+		 LIMIT NULLIF($5, -1) + 1`,
+		userID, afterSeq, opts.UnreadOnly, nullableTime(opts.Since), limit)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	messages, err := scanMessages(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if opts.Limit > 0 && len(messages) > opts.Limit {
+		messages = messages[:opts.Limit]
+		nextCursor = strconv.FormatInt(messages[len(messages)-1].Seq, 10)
+	}
+
+	return messages, nextCursor, nil
+}
+
+func (s *Store) ListThread(ctx context.Context, rootID int64) ([]store.Message, error) {
+	rows, err := s.conn.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM messages WHERE id = $1 OR reply_to = $1 ORDER BY id`, rootID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMessage(row rowScanner) (*store.Message, error) {
+	var msg store.Message
+	var readAt sql.NullTime
+	var replyTo sql.NullInt64
+
+	if err := row.Scan(&msg.ID, &msg.Sender, &msg.Time, &msg.Payload, &readAt, &replyTo); err != nil {
+		return nil, err
+	}
+
+	msg.Seq = msg.ID
+	if readAt.Valid {
+		msg.ReadAt = &readAt.Time
+	}
+	if replyTo.Valid {
+		msg.ReplyTo = &replyTo.Int64
+	}
+	return &msg, nil
+}
+
+func scanMessages(rows *sql.Rows) ([]store.Message, error) {
+	var messages []store.Message
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *msg)
+	}
+	return messages, rows.Err()
+}
+
+// isUniqueViolation reports whether err is a Postgres unique
+// constraint violation (SQLSTATE 23505), without pulling in the pgx
+// error types package for a single code comparison.
+func isUniqueViolation(err error) bool {
+	type sqlState interface{ SQLState() string }
+	var pgErr sqlState
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "23505"
+}
@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+	"github.com/hairutdin/alexios/internal/store/storetest"
+)
+
+// TestStore runs the shared conformance suite against a real Postgres
+// instance. It's skipped by default since it needs a live database;
+// set TEST_DATABASE_URI to run it, e.g. in CI.
+func TestStore(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URI")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URI not set, skipping Postgres conformance tests")
+	}
+
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s, err := Open(dsn)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if _, err := s.conn.Exec(`TRUNCATE messages, users RESTART IDENTITY`); err != nil {
+			t.Fatalf("truncate tables: %v", err)
+		}
+		return s
+	})
+}
+
+// TestStoreSubscribe exercises Subscribe's LISTEN/NOTIFY wiring against
+// a real Postgres instance; like TestStore, it needs TEST_DATABASE_URI.
+func TestStoreSubscribe(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URI")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URI not set, skipping Postgres notification tests")
+	}
+
+	s, err := Open(dsn)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := s.conn.Exec(`TRUNCATE messages, users RESTART IDENTITY`); err != nil {
+		t.Fatalf("truncate tables: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs, err := s.Subscribe(ctx, "recipient-1")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := s.SaveMessage(context.Background(), "recipient-1", store.Message{
+		Sender:  "sender-1",
+		Time:    time.Now(),
+		Payload: "pushed",
+	}); err != nil {
+		t.Fatalf("SaveMessage() error = %v", err)
+	}
+
+	select {
+	case msg := <-msgs:
+		if msg.Payload != "pushed" {
+			t.Errorf("msg.Payload = %q, want %q", msg.Payload, "pushed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notified message")
+	}
+}
@@ -0,0 +1,252 @@
+// Package redis is a Redis store.Store backend. Messages are stored
+// as hashes (message:<id>) and each user's inbox as a list of message
+// IDs (inbox:<userID>), so ListMessages is an LRANGE followed by one
+// HGETALL per message.
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func init() {
+	store.Register("redis", func(dsn string) (store.Store, error) {
+		return Open(dsn)
+	})
+}
+
+const (
+	usernameKeyPrefix = "user:username:"
+	messageKeyPrefix  = "message:"
+	inboxKeyPrefix    = "inbox:"
+	threadKeyPrefix   = "thread:"
+	nextMessageIDKey  = "message:next_id"
+)
+
+// Store is the Redis-backed store.Store implementation.
+type Store struct {
+	client *goredis.Client
+}
+
+// Open connects to the Redis instance described by dsn, a
+// redis://[user:pass@]host:port[/db] URL.
+func Open(dsn string) (*Store, error) {
+	opts, err := goredis.ParseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("redis: invalid dsn: %w", err)
+	}
+	return &Store{client: goredis.NewClient(opts)}, nil
+}
+
+func (s *Store) RegisterUser(ctx context.Context, userID, username string) error {
+	ok, err := s.client.SetNX(ctx, usernameKeyPrefix+username, userID, 0).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return store.ErrConflict
+	}
+	return nil
+}
+
+func (s *Store) FindRecepient(ctx context.Context, username string) (string, error) {
+	userID, err := s.client.Get(ctx, usernameKeyPrefix+username).Result()
+	if err == goredis.Nil {
+		return "", store.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+func (s *Store) SaveMessage(ctx context.Context, userID string, msg store.Message) error {
+	id, err := s.client.Incr(ctx, nextMessageIDKey).Result()
+	if err != nil {
+		return err
+	}
+	msg.ID = id
+	msg.Seq = id
+
+	replyTo := ""
+	if msg.ReplyTo != nil {
+		replyTo = strconv.FormatInt(*msg.ReplyTo, 10)
+	}
+
+	key := messageKey(id)
+	if err := s.client.HSet(ctx, key,
+		"sender", msg.Sender,
+		"sent_at", msg.Time.Format(time.RFC3339Nano),
+		"payload", msg.Payload,
+		"read_at", "",
+		"reply_to", replyTo,
+	).Err(); err != nil {
+		return err
+	}
+
+	if err := s.client.RPush(ctx, inboxKeyPrefix+userID, id).Err(); err != nil {
+		return err
+	}
+
+	if msg.ReplyTo != nil {
+		if err := s.client.RPush(ctx, threadKeyPrefix+replyTo, id).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) ListMessages(ctx context.Context, userID string) ([]store.Message, error) {
+	ids, err := s.client.LRange(ctx, inboxKeyPrefix+userID, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	return s.getMessages(ctx, ids)
+}
+
+func (s *Store) GetMessage(ctx context.Context, id int64) (*store.Message, error) {
+	return s.getMessage(ctx, id)
+}
+
+func (s *Store) MarkRead(ctx context.Context, id int64) error {
+	key := messageKey(id)
+	exists, err := s.client.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return store.ErrNotFound
+	}
+	return s.client.HSet(ctx, key, "read_at", time.Now().Format(time.RFC3339Nano)).Err()
+}
+
+func (s *Store) ListMessagesPage(ctx context.Context, userID string, opts store.ListOpts) ([]store.Message, string, error) {
+	var afterSeq int64
+	if opts.Cursor != "" {
+		parsed, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		afterSeq = parsed
+	}
+
+	ids, err := s.client.LRange(ctx, inboxKeyPrefix+userID, 0, -1).Result()
+	if err != nil {
+		return nil, "", err
+	}
+
+	messages, err := s.getMessages(ctx, ids)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var filtered []store.Message
+	for _, msg := range messages {
+		if msg.Seq <= afterSeq {
+			continue
+		}
+		if opts.UnreadOnly && msg.ReadAt != nil {
+			continue
+		}
+		if !opts.Since.IsZero() && msg.Time.Before(opts.Since) {
+			continue
+		}
+		filtered = append(filtered, msg)
+	}
+
+	page := filtered
+	var nextCursor string
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		page = filtered[:opts.Limit]
+		nextCursor = strconv.FormatInt(page[len(page)-1].Seq, 10)
+	}
+
+	return page, nextCursor, nil
+}
+
+func (s *Store) ListThread(ctx context.Context, rootID int64) ([]store.Message, error) {
+	root, err := s.getMessage(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	replyIDs, err := s.client.LRange(ctx, threadKeyPrefix+strconv.FormatInt(rootID, 10), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := s.getMessages(ctx, replyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]store.Message{*root}, replies...), nil
+}
+
+func (s *Store) getMessages(ctx context.Context, ids []string) ([]store.Message, error) {
+	messages := make([]store.Message, 0, len(ids))
+	for _, idStr := range ids {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		msg, err := s.getMessage(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *msg)
+	}
+	return messages, nil
+}
+
+func (s *Store) getMessage(ctx context.Context, id int64) (*store.Message, error) {
+	fields, err := s.client.HGetAll(ctx, messageKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	sentAt, err := time.Parse(time.RFC3339Nano, fields["sent_at"])
+	if err != nil {
+		return nil, fmt.Errorf("redis: malformed sent_at for message %d: %w", id, err)
+	}
+
+	msg := &store.Message{
+		ID:      id,
+		Seq:     id,
+		Sender:  fields["sender"],
+		Time:    sentAt,
+		Payload: fields["payload"],
+	}
+
+	if readAt := fields["read_at"]; readAt != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, readAt)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed read_at for message %d: %w", id, err)
+		}
+		msg.ReadAt = &parsed
+	}
+
+	if replyTo := fields["reply_to"]; replyTo != "" {
+		parsed, err := strconv.ParseInt(replyTo, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed reply_to for message %d: %w", id, err)
+		}
+		msg.ReplyTo = &parsed
+	}
+
+	return msg, nil
+}
+
+func messageKey(id int64) string {
+	return messageKeyPrefix + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+	"github.com/hairutdin/alexios/internal/store/storetest"
+)
+
+// TestStore runs the shared conformance suite against a real Redis
+// instance. It's skipped by default since it needs a live server; set
+// TEST_REDIS_URI to run it, e.g. "redis://localhost:6379/0" in CI.
+func TestStore(t *testing.T) {
+	dsn := os.Getenv("TEST_REDIS_URI")
+	if dsn == "" {
+		t.Skip("TEST_REDIS_URI not set, skipping Redis conformance tests")
+	}
+
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s, err := Open(dsn)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.client.FlushDB(ctx).Err(); err != nil {
+			t.Fatalf("FlushDB() error = %v", err)
+		}
+
+		return s
+	})
+}
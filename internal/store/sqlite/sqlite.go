@@ -0,0 +1,237 @@
+// Package sqlite is a SQLite store.Store backend, using the pure-Go
+// modernc.org/sqlite driver so the binary doesn't need cgo.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	store.Register("sqlite", func(dsn string) (store.Store, error) {
+		return Open(dsn)
+	})
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS users (
+	user_id  TEXT PRIMARY KEY,
+	username TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	sender       TEXT NOT NULL,
+	recipient_id TEXT NOT NULL,
+	sent_at      DATETIME NOT NULL,
+	payload      TEXT NOT NULL,
+	read_at      DATETIME NULL,
+	reply_to     INTEGER NULL REFERENCES messages (id)
+);
+
+CREATE INDEX IF NOT EXISTS messages_recipient_id_idx ON messages (recipient_id);
+CREATE INDEX IF NOT EXISTS messages_reply_to_idx ON messages (reply_to);
+`
+
+const messageColumns = `id, sender, sent_at, payload, read_at, reply_to`
+
+// Store is the SQLite-backed store.Store implementation.
+type Store struct {
+	conn *sql.DB
+}
+
+// Open opens (and creates, if missing) the SQLite database at dsn,
+// e.g. a file path or ":memory:", and ensures the schema exists.
+func Open(dsn string) (*Store, error) {
+	conn, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only supports one writer at a time; modernc's driver
+	// doesn't pool connections safely under concurrent writes.
+	conn.SetMaxOpenConns(1)
+
+	if _, err := conn.Exec(schema); err != nil {
+		return nil, err
+	}
+
+	return &Store{conn: conn}, nil
+}
+
+func (s *Store) RegisterUser(ctx context.Context, userID, username string) error {
+	_, err := s.conn.ExecContext(ctx,
+		`INSERT INTO users (user_id, username) VALUES (?, ?)`, userID, username)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return store.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Store) FindRecepient(ctx context.Context, username string) (string, error) {
+	var userID string
+	err := s.conn.QueryRowContext(ctx,
+		`SELECT user_id FROM users WHERE username = ?`, username).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+func (s *Store) SaveMessage(ctx context.Context, userID string, msg store.Message) error {
+	var replyTo sql.NullInt64
+	if msg.ReplyTo != nil {
+		replyTo = sql.NullInt64{Int64: *msg.ReplyTo, Valid: true}
+	}
+
+	res, err := s.conn.ExecContext(ctx,
+		`INSERT INTO messages (sender, recipient_id, sent_at, payload, reply_to) VALUES (?, ?, ?, ?, ?)`,
+		msg.Sender, userID, msg.Time, msg.Payload, replyTo)
+	if err != nil {
+		return err
+	}
+	_, err = res.LastInsertId()
+	return err
+}
+
+func (s *Store) ListMessages(ctx context.Context, userID string) ([]store.Message, error) {
+	rows, err := s.conn.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM messages WHERE recipient_id = ? ORDER BY id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+func (s *Store) GetMessage(ctx context.Context, id int64) (*store.Message, error) {
+	row := s.conn.QueryRowContext(ctx,
+		`SELECT `+messageColumns+` FROM messages WHERE id = ?`, id)
+	msg, err := scanMessage(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *Store) MarkRead(ctx context.Context, id int64) error {
+	res, err := s.conn.ExecContext(ctx, `UPDATE messages SET read_at = ? WHERE id = ?`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) ListMessagesPage(ctx context.Context, userID string, opts store.ListOpts) ([]store.Message, string, error) {
+	var afterSeq int64
+	if opts.Cursor != "" {
+		parsed, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		afterSeq = parsed
+	}
+
+	query := `SELECT ` + messageColumns + ` FROM messages WHERE recipient_id = ? AND id > ?`
+	args := []any{userID, afterSeq}
+
+	if opts.UnreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	if !opts.Since.IsZero() {
+		query += ` AND sent_at >= ?`
+		args = append(args, opts.Since)
+	}
+	query += ` ORDER BY id`
+	if opts.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, opts.Limit+1)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	messages, err := scanMessages(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if opts.Limit > 0 && len(messages) > opts.Limit {
+		messages = messages[:opts.Limit]
+		nextCursor = strconv.FormatInt(messages[len(messages)-1].Seq, 10)
+	}
+
+	return messages, nextCursor, nil
+}
+
+func (s *Store) ListThread(ctx context.Context, rootID int64) ([]store.Message, error) {
+	rows, err := s.conn.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM messages WHERE id = ? OR reply_to = ? ORDER BY id`, rootID, rootID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMessage(row rowScanner) (*store.Message, error) {
+	var msg store.Message
+	var readAt sql.NullTime
+	var replyTo sql.NullInt64
+
+	if err := row.Scan(&msg.ID, &msg.Sender, &msg.Time, &msg.Payload, &readAt, &replyTo); err != nil {
+		return nil, err
+	}
+
+	msg.Seq = msg.ID
+	if readAt.Valid {
+		msg.ReadAt = &readAt.Time
+	}
+	if replyTo.Valid {
+		msg.ReplyTo = &replyTo.Int64
+	}
+	return &msg, nil
+}
+
+func scanMessages(rows *sql.Rows) ([]store.Message, error) {
+	var messages []store.Message
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *msg)
+	}
+	return messages, rows.Err()
+}
@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/hairutdin/alexios/internal/store"
+	"github.com/hairutdin/alexios/internal/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s, err := Open(":memory:")
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		t.Cleanup(func() { s.conn.Close() })
+		return s
+	})
+}
@@ -2,14 +2,64 @@ package store
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
+// ErrNotFound is returned when a message or recipient doesn't exist.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrConflict is returned by RegisterUser when the requested username
+// is already taken.
+var ErrConflict = errors.New("store: already exists")
+
 type Store interface {
 	FindRecepient(ctx context.Context, username string) (userID string, err error)
 	ListMessages(ctx context.Context, userID string) ([]Message, error)
 	GetMessage(ctx context.Context, id int64) (*Message, error)
 	SaveMessage(ctx context.Context, userID string, msg Message) error
+	RegisterUser(ctx context.Context, userID, username string) error
+
+	// MarkRead records that a message has been played back to its
+	// recipient, so it's excluded by ListMessagesPage's UnreadOnly.
+	MarkRead(ctx context.Context, id int64) error
+	// ListMessagesPage returns a page of userID's messages ordered by
+	// Seq, along with a cursor for the next page (empty once
+	// exhausted).
+	ListMessagesPage(ctx context.Context, userID string, opts ListOpts) ([]Message, string, error)
+	// ListThread returns the message rootID plus every message sent in
+	// reply to it, ordered by Seq.
+	ListThread(ctx context.Context, rootID int64) ([]Message, error)
+}
+
+// Notifier is implemented by Store backends that can push newly saved
+// messages to interested callers in real time, instead of requiring
+// them to poll ListMessages. Not every backend supports it; callers
+// type-assert a Store to Notifier to find out whether it does
+// (internal/api answers its streaming endpoints with 501 when it
+// doesn't), e.g.:
+//
+//	notifier, ok := s.(store.Notifier)
+type Notifier interface {
+	// Subscribe returns a channel delivering messages saved for userID
+	// after the call returns, until ctx is cancelled. The channel is
+	// closed once the subscription ends; callers must keep draining it
+	// to avoid leaking the subscription's resources.
+	Subscribe(ctx context.Context, userID string) (<-chan Message, error)
+}
+
+// ListOpts narrows a ListMessagesPage query.
+type ListOpts struct {
+	// Cursor is the opaque token returned by a previous
+	// ListMessagesPage call; zero value starts from the beginning.
+	Cursor string
+	// Limit caps the number of messages returned; <= 0 means no cap.
+	Limit int
+	// UnreadOnly excludes messages that have already been MarkRead.
+	UnreadOnly bool
+	// Since, if non-zero, excludes messages sent before this time.
+	Since time.Time
 }
 
 type Message struct {
@@ -17,4 +67,42 @@ type Message struct {
 	Sender  string
 	Time    time.Time
 	Payload string
+
+	// Seq is a monotonically increasing sequence number assigned at
+	// save time, used to order and paginate a user's messages.
+	Seq int64
+	// ReadAt is set once MarkRead has been called for this message.
+	ReadAt *time.Time
+	// ReplyTo is the ID of the message this one replies to, if any.
+	ReplyTo *int64
+}
+
+// OpenFunc constructs a Store from a driver-specific DSN. Backends
+// register themselves under a driver name via Register, the same way
+// database/sql drivers register via sql.Register.
+type OpenFunc func(dsn string) (Store, error)
+
+var drivers = make(map[string]OpenFunc)
+
+// Register makes a Store backend available under driver for Open. It
+// is meant to be called from a backend package's init function, e.g.
+// internal/store/memory registers itself as "memory". Register panics
+// if called twice with the same driver name.
+func Register(driver string, open OpenFunc) {
+	if _, exists := drivers[driver]; exists {
+		panic("store: Register called twice for driver " + driver)
+	}
+	drivers[driver] = open
+}
+
+// Open constructs the Store registered under driver, passing it dsn.
+// The caller must blank-import the backend package (e.g.
+// `_ "github.com/hairutdin/alexios/internal/store/memory"`) so its
+// init function has registered the driver.
+func Open(driver, dsn string) (Store, error) {
+	open, ok := drivers[driver]
+	if !ok {
+		return nil, fmt.Errorf("store: unknown driver %q (is it imported?)", driver)
+	}
+	return open(dsn)
 }
@@ -0,0 +1,246 @@
+// Package storetest is a shared conformance suite for store.Store
+// backends. Every backend (memory, pg, sqlite, redis, ...) should pass
+// it via its own *_test.go calling storetest.Run.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hairutdin/alexios/internal/store"
+)
+
+// Run exercises the full store.Store contract against a freshly
+// constructed backend. newStore is called once per subtest so backends
+// can return an isolated instance (e.g. a fresh in-memory map or a
+// truncated table).
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	t.Helper()
+
+	t.Run("RegisterUser", func(t *testing.T) { testRegisterUser(t, newStore(t)) })
+	t.Run("RegisterUserConflict", func(t *testing.T) { testRegisterUserConflict(t, newStore(t)) })
+	t.Run("FindRecepientNotFound", func(t *testing.T) { testFindRecepientNotFound(t, newStore(t)) })
+	t.Run("SaveAndListMessages", func(t *testing.T) { testSaveAndListMessages(t, newStore(t)) })
+	t.Run("GetMessage", func(t *testing.T) { testGetMessage(t, newStore(t)) })
+	t.Run("GetMessageNotFound", func(t *testing.T) { testGetMessageNotFound(t, newStore(t)) })
+	t.Run("MarkRead", func(t *testing.T) { testMarkRead(t, newStore(t)) })
+	t.Run("ListMessagesPage", func(t *testing.T) { testListMessagesPage(t, newStore(t)) })
+	t.Run("ListThread", func(t *testing.T) { testListThread(t, newStore(t)) })
+}
+
+func testRegisterUser(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.RegisterUser(ctx, "user-1", "alice"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+
+	userID, err := s.FindRecepient(ctx, "alice")
+	if err != nil {
+		t.Fatalf("FindRecepient() error = %v", err)
+	}
+	if userID != "user-1" {
+		t.Errorf("FindRecepient() = %q, want %q", userID, "user-1")
+	}
+}
+
+func testRegisterUserConflict(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.RegisterUser(ctx, "user-1", "bob"); err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+
+	err := s.RegisterUser(ctx, "user-2", "bob")
+	if !errors.Is(err, store.ErrConflict) {
+		t.Fatalf("RegisterUser() error = %v, want %v", err, store.ErrConflict)
+	}
+}
+
+func testFindRecepientNotFound(t *testing.T, s store.Store) {
+	_, err := s.FindRecepient(context.Background(), "nobody")
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("FindRecepient() error = %v, want %v", err, store.ErrNotFound)
+	}
+}
+
+func testSaveAndListMessages(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveMessage(ctx, "recipient-1", store.Message{
+		Sender:  "sender-1",
+		Time:    time.Now(),
+		Payload: "hello",
+	}); err != nil {
+		t.Fatalf("SaveMessage() error = %v", err)
+	}
+
+	messages, err := s.ListMessages(ctx, "recipient-1")
+	if err != nil {
+		t.Fatalf("ListMessages() error = %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("len(ListMessages()) = %d, want 1", len(messages))
+	}
+	if messages[0].Payload != "hello" {
+		t.Errorf("messages[0].Payload = %q, want %q", messages[0].Payload, "hello")
+	}
+	if messages[0].ID == 0 {
+		t.Errorf("messages[0].ID = 0, want a non-zero assigned ID")
+	}
+
+	others, err := s.ListMessages(ctx, "recipient-2")
+	if err != nil {
+		t.Fatalf("ListMessages(recipient-2) error = %v", err)
+	}
+	if len(others) != 0 {
+		t.Errorf("len(ListMessages(recipient-2)) = %d, want 0", len(others))
+	}
+}
+
+func testGetMessage(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveMessage(ctx, "recipient-1", store.Message{
+		Sender:  "sender-1",
+		Time:    time.Now(),
+		Payload: "hello again",
+	}); err != nil {
+		t.Fatalf("SaveMessage() error = %v", err)
+	}
+
+	messages, err := s.ListMessages(ctx, "recipient-1")
+	if err != nil {
+		t.Fatalf("ListMessages() error = %v", err)
+	}
+	if len(messages) == 0 {
+		t.Fatalf("ListMessages() returned no messages")
+	}
+
+	got, err := s.GetMessage(ctx, messages[0].ID)
+	if err != nil {
+		t.Fatalf("GetMessage() error = %v", err)
+	}
+	if got.Payload != "hello again" {
+		t.Errorf("GetMessage().Payload = %q, want %q", got.Payload, "hello again")
+	}
+}
+
+func testGetMessageNotFound(t *testing.T, s store.Store) {
+	_, err := s.GetMessage(context.Background(), 999999)
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("GetMessage() error = %v, want %v", err, store.ErrNotFound)
+	}
+}
+
+func testMarkRead(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveMessage(ctx, "recipient-1", store.Message{Sender: "sender-1", Time: time.Now(), Payload: "unread"}); err != nil {
+		t.Fatalf("SaveMessage() error = %v", err)
+	}
+
+	messages, err := s.ListMessages(ctx, "recipient-1")
+	if err != nil {
+		t.Fatalf("ListMessages() error = %v", err)
+	}
+	id := messages[len(messages)-1].ID
+
+	if err := s.MarkRead(ctx, id); err != nil {
+		t.Fatalf("MarkRead() error = %v", err)
+	}
+
+	got, err := s.GetMessage(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMessage() error = %v", err)
+	}
+	if got.ReadAt == nil {
+		t.Fatalf("GetMessage().ReadAt = nil, want non-nil after MarkRead")
+	}
+
+	err = s.MarkRead(ctx, 999999)
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("MarkRead(unknown id) error = %v, want %v", err, store.ErrNotFound)
+	}
+}
+
+func testListMessagesPage(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	const userID = "recipient-page"
+
+	for i := 0; i < 3; i++ {
+		if err := s.SaveMessage(ctx, userID, store.Message{Sender: "sender-1", Time: time.Now(), Payload: "msg"}); err != nil {
+			t.Fatalf("SaveMessage() error = %v", err)
+		}
+	}
+
+	page1, cursor1, err := s.ListMessagesPage(ctx, userID, store.ListOpts{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListMessagesPage() error = %v", err)
+	}
+	if len(page1) != 2 {
+		t.Fatalf("len(page1) = %d, want 2", len(page1))
+	}
+	if cursor1 == "" {
+		t.Fatalf("cursor1 is empty, want a token for the remaining page")
+	}
+
+	page2, cursor2, err := s.ListMessagesPage(ctx, userID, store.ListOpts{Limit: 2, Cursor: cursor1})
+	if err != nil {
+		t.Fatalf("ListMessagesPage() (page 2) error = %v", err)
+	}
+	if len(page2) != 1 {
+		t.Fatalf("len(page2) = %d, want 1", len(page2))
+	}
+	if cursor2 != "" {
+		t.Fatalf("cursor2 = %q, want empty once exhausted", cursor2)
+	}
+
+	if err := s.MarkRead(ctx, page1[0].ID); err != nil {
+		t.Fatalf("MarkRead() error = %v", err)
+	}
+
+	unread, _, err := s.ListMessagesPage(ctx, userID, store.ListOpts{UnreadOnly: true})
+	if err != nil {
+		t.Fatalf("ListMessagesPage(UnreadOnly) error = %v", err)
+	}
+	for _, msg := range unread {
+		if msg.ID == page1[0].ID {
+			t.Errorf("ListMessagesPage(UnreadOnly) still returned marked-read message %d", msg.ID)
+		}
+	}
+}
+
+func testListThread(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	const userID = "recipient-thread"
+
+	if err := s.SaveMessage(ctx, userID, store.Message{Sender: "sender-1", Time: time.Now(), Payload: "root"}); err != nil {
+		t.Fatalf("SaveMessage(root) error = %v", err)
+	}
+	messages, err := s.ListMessages(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListMessages() error = %v", err)
+	}
+	rootID := messages[len(messages)-1].ID
+
+	if err := s.SaveMessage(ctx, userID, store.Message{Sender: "sender-2", Time: time.Now(), Payload: "reply", ReplyTo: &rootID}); err != nil {
+		t.Fatalf("SaveMessage(reply) error = %v", err)
+	}
+
+	thread, err := s.ListThread(ctx, rootID)
+	if err != nil {
+		t.Fatalf("ListThread() error = %v", err)
+	}
+	if len(thread) != 2 {
+		t.Fatalf("len(thread) = %d, want 2", len(thread))
+	}
+	if thread[0].ID != rootID {
+		t.Errorf("thread[0].ID = %d, want root %d", thread[0].ID, rootID)
+	}
+	if thread[1].ReplyTo == nil || *thread[1].ReplyTo != rootID {
+		t.Errorf("thread[1].ReplyTo = %v, want %d", thread[1].ReplyTo, rootID)
+	}
+}